@@ -0,0 +1,186 @@
+package analyze
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/lkarlslund/adalanche/modules/engine"
+)
+
+// bloodhoundEdgeNamespace prefixes Adalanche edges that have no native
+// BloodHound equivalent, so they show up under their own custom edge kind
+// instead of silently colliding with (or being dropped in favour of)
+// BloodHound's built-in vocabulary.
+const bloodhoundEdgeNamespace = "AZAdalanche"
+
+// bloodhoundEdgeKinds translates Adalanche's engine.Edge vocabulary into
+// BloodHound OpenGraph edge kinds. Edges with a direct BloodHound
+// equivalent map to BloodHound's own name; Adalanche-specific edges
+// (SeImpersonate, AutoAdminLogonCreds, SIDCollision, ...) are preserved
+// under bloodhoundEdgeNamespace rather than dropped or merged into a
+// lookalike BloodHound kind.
+var bloodhoundEdgeKinds = map[engine.Edge]string{
+	EdgeLocalAdminRights:             "AdminTo",
+	EdgeLocalRDPRights:               "CanRDP",
+	EdgeLocalDCOMRights:              "ExecuteDCOM",
+	EdgeMemberOfGroup:                "MemberOf",
+	EdgeExecuted:                     "Executed",
+	EdgeHosts:                        "Hosts",
+	EdgeRunsExecutable:               bloodhoundEdgeNamespace + "_RunsExecutable",
+	EdgeHasServiceAccountCredentials: bloodhoundEdgeNamespace + "_SvcAccntCreds",
+	EdgeHasAutoAdminLogonCredentials: bloodhoundEdgeNamespace + "_AutoAdminLogonCreds",
+	EdgeSeImpersonate:                bloodhoundEdgeNamespace + "_SeImpersonate",
+	EdgeSeAssignPrimaryToken:         bloodhoundEdgeNamespace + "_SeAssignPrimaryToken",
+	EdgeSeCreateToken:                bloodhoundEdgeNamespace + "_SeCreateToken",
+	EdgeSeDebug:                      bloodhoundEdgeNamespace + "_SeDebug",
+	EdgeSeLoadDriver:                 bloodhoundEdgeNamespace + "_SeLoadDriver",
+	EdgeSeManageVolume:               bloodhoundEdgeNamespace + "_SeManageVolume",
+	EdgeSeTakeOwnership:              bloodhoundEdgeNamespace + "_SeTakeOwnership",
+	EdgeSeTrustedCredManAccess:       bloodhoundEdgeNamespace + "_SeTrustedCredManAccess",
+	EdgeSeTcb:                        bloodhoundEdgeNamespace + "_SeTcb",
+	EdgeSeBackupPrivilege:            bloodhoundEdgeNamespace + "_SeBackupPrivilege",
+	EdgeSeRestorePrivilege:           bloodhoundEdgeNamespace + "_SeRestorePrivilege",
+	EdgeSeTakeOwnershipPrivilege:     bloodhoundEdgeNamespace + "_SeTakeOwnershipPrivilege",
+	EdgeSeNetworkLogonRight:          bloodhoundEdgeNamespace + "_SeNetworkLogonRight",
+	EdgeSIDCollision:                 bloodhoundEdgeNamespace + "_SIDCollision",
+	EdgeRegistryOwns:                 bloodhoundEdgeNamespace + "_RegistryOwns",
+	EdgeRegistryWrite:                bloodhoundEdgeNamespace + "_RegistryWrite",
+	EdgeRegistryModifyDACL:           bloodhoundEdgeNamespace + "_RegistryModifyDACL",
+	EdgeRegistryModifyOwner:          bloodhoundEdgeNamespace + "_RegistryModifyOwner",
+	EdgeFileWrite:                    bloodhoundEdgeNamespace + "_FileWrite",
+	EdgeFileRead:                     bloodhoundEdgeNamespace + "_FileRead",
+	EdgeShares:                       bloodhoundEdgeNamespace + "_Shares",
+	EdgeControlsUpdates:              bloodhoundEdgeNamespace + "_ControlsUpdates",
+	EdgePublishes:                    bloodhoundEdgeNamespace + "_Publishes",
+}
+
+// bloodhoundKind returns the BloodHound OpenGraph edge kind for an
+// Adalanche edge, falling back to the raw edge name under
+// bloodhoundEdgeNamespace if no explicit mapping was registered.
+func bloodhoundKind(edge engine.Edge) string {
+	if kind, found := bloodhoundEdgeKinds[edge]; found {
+		return kind
+	}
+	return bloodhoundEdgeNamespace + "_" + edge.String()
+}
+
+// OpenGraphNode is a single node in the BloodHound OpenGraph schema.
+type OpenGraphNode struct {
+	ID         string         `json:"id"`
+	Kinds      []string       `json:"kinds"`
+	Properties map[string]any `json:"properties,omitempty"`
+}
+
+// OpenGraphEdge is a single edge in the BloodHound OpenGraph schema.
+type OpenGraphEdge struct {
+	Kind       string         `json:"kind"`
+	Start      OpenGraphID    `json:"start"`
+	End        OpenGraphID    `json:"end"`
+	Properties map[string]any `json:"properties,omitempty"`
+}
+
+// OpenGraphID references a node by its id.
+type OpenGraphID struct {
+	Value string `json:"value"`
+}
+
+// OpenGraph is the top level payload expected by BloodHound's OpenGraph
+// ingestion API.
+type OpenGraph struct {
+	Metadata OpenGraphMetadata `json:"metadata"`
+	Graph    struct {
+		Nodes []OpenGraphNode `json:"nodes"`
+		Edges []OpenGraphEdge `json:"edges"`
+	} `json:"graph"`
+}
+
+// OpenGraphMetadata identifies the collector that produced the graph.
+type OpenGraphMetadata struct {
+	SourceKind string `json:"source_kind"`
+}
+
+// BuildOpenGraph walks objs and converts it into the BloodHound-compatible
+// OpenGraph representation, with each edge's properties carrying the
+// probability from its registered engine.RegisterProbabilityCalculator (or
+// its static probability, if it has none). onObject, if non-nil, is
+// called once per object processed, for progress reporting.
+func BuildOpenGraph(objs *engine.Objects, onObject func()) OpenGraph {
+	var og OpenGraph
+	og.Metadata.SourceKind = "AZAdalanche"
+
+	objs.Iterate(func(o *engine.Object) bool {
+		if onObject != nil {
+			onObject()
+		}
+
+		node := OpenGraphNode{
+			ID:    o.SID().String(),
+			Kinds: []string{"Base", o.Type().String()},
+			Properties: map[string]any{
+				"name": o.Label(),
+			},
+		}
+		og.Graph.Nodes = append(og.Graph.Nodes, node)
+
+		o.Edges(engine.Out).Range(func(target *engine.Object, edgebits engine.EdgeBitmap) bool {
+			edgebits.Range(func(edge engine.Edge) bool {
+				og.Graph.Edges = append(og.Graph.Edges, OpenGraphEdge{
+					Kind:  bloodhoundKind(edge),
+					Start: OpenGraphID{Value: o.SID().String()},
+					End:   OpenGraphID{Value: target.SID().String()},
+					Properties: map[string]any{
+						"probability":   EdgeProbability(edge, edgebits, o, target),
+						"adalancheEdge": edge.String(),
+					},
+				})
+				return true
+			})
+			return true
+		})
+		return true
+	})
+
+	return og
+}
+
+// ExportOpenGraph writes the BloodHound OpenGraph representation of objs as
+// a single JSON document. onObject, if non-nil, is called once per object
+// processed, for progress reporting.
+func ExportOpenGraph(objs *engine.Objects, onObject func(), w io.Writer) error {
+	og := BuildOpenGraph(objs, onObject)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(og)
+}
+
+// ExportOpenGraphSplit writes the BloodHound OpenGraph representation of
+// objs as separate nodes.json / edges.json files under dir, for ingestion
+// into external graph tools that prefer nodes and edges split apart.
+// onObject, if non-nil, is called once per object processed, for progress
+// reporting.
+func ExportOpenGraphSplit(objs *engine.Objects, onObject func(), dir string) error {
+	og := BuildOpenGraph(objs, onObject)
+
+	if err := writeJSONFile(filepath.Join(dir, "nodes.json"), og.Graph.Nodes); err != nil {
+		return err
+	}
+	if err := writeJSONFile(filepath.Join(dir, "edges.json"), og.Graph.Edges); err != nil {
+		return err
+	}
+	return nil
+}
+
+func writeJSONFile(path string, data any) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating %v: %w", path, err)
+	}
+	defer f.Close()
+
+	encoder := json.NewEncoder(f)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(data)
+}
@@ -0,0 +1,39 @@
+package analyze
+
+import (
+	"testing"
+
+	"github.com/lkarlslund/adalanche/modules/engine"
+)
+
+func TestBloodhoundKind(t *testing.T) {
+	tests := []struct {
+		name string
+		edge engine.Edge
+		want string
+	}{
+		{
+			name: "edge with a direct BloodHound equivalent",
+			edge: EdgeLocalAdminRights,
+			want: "AdminTo",
+		},
+		{
+			name: "adalanche-specific edge uses the custom namespace",
+			edge: EdgeSeImpersonate,
+			want: bloodhoundEdgeNamespace + "_SeImpersonate",
+		},
+		{
+			name: "unmapped edge falls back to its own name under the custom namespace",
+			edge: engine.NewEdge("SomeFutureEdge"),
+			want: bloodhoundEdgeNamespace + "_SomeFutureEdge",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := bloodhoundKind(tt.edge); got != tt.want {
+				t.Errorf("bloodhoundKind() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package analyze
+
+import (
+	"time"
+
+	"github.com/lkarlslund/adalanche/modules/engine"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	httpRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "adalanche",
+		Subsystem: "webservice",
+		Name:      "http_requests_total",
+		Help:      "Total number of HTTP requests handled by the webservice, by route and status.",
+	}, []string{"route", "method", "status"})
+
+	httpRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "adalanche",
+		Subsystem: "webservice",
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency, by route and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method", "status"})
+
+	httpResponseSize = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "adalanche",
+		Subsystem: "webservice",
+		Name:      "http_response_size_bytes",
+		Help:      "HTTP response size, by route.",
+		Buckets:   prometheus.ExponentialBuckets(64, 4, 8),
+	}, []string{"route", "method"})
+
+	objectsLoaded = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "adalanche",
+		Subsystem: "graph",
+		Name:      "objects_loaded",
+		Help:      "Number of objects currently held in the analyzed graph.",
+	})
+
+	edgesByKind = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "adalanche",
+		Subsystem: "graph",
+		Name:      "edges",
+		Help:      "Number of edges in the analyzed graph, by edge kind.",
+	}, []string{"kind"})
+
+	analysisPassDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "adalanche",
+		Subsystem: "analysis",
+		Name:      "pass_duration_seconds",
+		Help:      "Duration of a bulk pass a subcommand runs over the analyzed graph (e.g. bloodhound-export, neo4j-upload, report). Does not cover the upstream collection/merge/edge resolution/SID translation/probability calculation pipeline that produces the graph - that pipeline isn't part of this command set.",
+		Buckets:   prometheus.ExponentialBuckets(0.01, 2, 12),
+	}, []string{"pass"})
+)
+
+// RecordPassDuration reports how long a subcommand's bulk pass over the
+// analyzed graph took, for the analysis_pass_duration_seconds histogram.
+func RecordPassDuration(pass string, d time.Duration) {
+	analysisPassDuration.WithLabelValues(pass).Observe(d.Seconds())
+}
+
+// updateGraphMetrics recomputes the objects_loaded gauge and the per-kind
+// edge gauges from the current object graph. Called whenever the
+// webservice is (re)started with a new *engine.Objects.
+func updateGraphMetrics(objs *engine.Objects) {
+	if objs == nil {
+		return
+	}
+
+	edgesByKind.Reset()
+	counts := map[string]float64{}
+
+	var objectCount float64
+	objs.Iterate(func(o *engine.Object) bool {
+		objectCount++
+		o.Edges(engine.Out).Range(func(target *engine.Object, edgebits engine.EdgeBitmap) bool {
+			edgebits.Range(func(edge engine.Edge) bool {
+				counts[edge.String()]++
+				return true
+			})
+			return true
+		})
+		return true
+	})
+
+	objectsLoaded.Set(objectCount)
+	for kind, count := range counts {
+		edgesByKind.WithLabelValues(kind).Set(count)
+	}
+}
@@ -0,0 +1,178 @@
+// Package neo4j streams an analyzed Adalanche object graph to a live Neo4j
+// instance over the Bolt protocol, as an alternative to serving the graph
+// from the embedded webservice.
+package neo4j
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lkarlslund/adalanche/modules/engine"
+	"github.com/lkarlslund/adalanche/modules/ui"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// Config holds the connection details and batching behaviour for an upload
+// run.
+type Config struct {
+	URI      string
+	Username string
+	Password string
+	Database string
+
+	// BatchSize is the number of nodes, or edges, sent per UNWIND/MERGE
+	// statement.
+	BatchSize int
+}
+
+// Uploader streams an engine.Objects graph to Neo4j over Bolt.
+type Uploader struct {
+	config Config
+	driver neo4j.DriverWithContext
+}
+
+// NewUploader opens a Bolt connection to the configured Neo4j instance and
+// verifies connectivity before returning.
+func NewUploader(ctx context.Context, config Config) (*Uploader, error) {
+	if config.BatchSize <= 0 {
+		config.BatchSize = 1000
+	}
+
+	driver, err := neo4j.NewDriverWithContext(config.URI, neo4j.BasicAuth(config.Username, config.Password, ""))
+	if err != nil {
+		return nil, fmt.Errorf("connecting to neo4j at %v: %w", config.URI, err)
+	}
+	if err := driver.VerifyConnectivity(ctx); err != nil {
+		return nil, fmt.Errorf("verifying connectivity to neo4j at %v: %w", config.URI, err)
+	}
+
+	return &Uploader{config: config, driver: driver}, nil
+}
+
+// Close releases the underlying Bolt driver.
+func (u *Uploader) Close(ctx context.Context) error {
+	return u.driver.Close(ctx)
+}
+
+// Upload streams all objects and edges in objs to Neo4j, batched into
+// UNWIND ... MERGE statements of the configured size. Nodes and edges are
+// merged on SID, so re-running Upload for the same or a smaller set of
+// objects refreshes them in place without wiping the rest of the database.
+//
+// Nodes are uploaded to completion before any edges are, in a separate
+// pass over objs. Edges MATCH their endpoint nodes rather than MERGE-ing
+// placeholders for them, so an edge batch that runs ahead of its
+// endpoints' node batch would otherwise silently drop rows instead of
+// erroring - doing all nodes first rules that out.
+//
+// onNode and onEdge, if non-nil, are called once per node and edge
+// uploaded respectively, for progress reporting.
+func (u *Uploader) Upload(ctx context.Context, objs *engine.Objects, onNode, onEdge func()) error {
+	session := u.driver.NewSession(ctx, neo4j.SessionConfig{DatabaseName: u.config.Database})
+	defer session.Close(ctx)
+
+	if err := u.uploadNodes(ctx, session, objs, onNode); err != nil {
+		return err
+	}
+	if err := u.uploadEdges(ctx, session, objs, onEdge); err != nil {
+		return err
+	}
+
+	ui.Info().Msg("Neo4j upload complete")
+	return nil
+}
+
+func (u *Uploader) uploadNodes(ctx context.Context, session neo4j.SessionWithContext, objs *engine.Objects, onNode func()) error {
+	var nodes []map[string]any
+
+	flush := func() error {
+		if len(nodes) == 0 {
+			return nil
+		}
+		_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+			return tx.Run(ctx, `
+				UNWIND $nodes AS node
+				MERGE (o:AdalancheObject {sid: node.sid})
+				SET o += node.properties
+				SET o:AdalancheObject
+				WITH o, node
+				CALL apoc.create.addLabels(o, node.kinds) YIELD node AS labeled
+				RETURN count(*)
+			`, map[string]any{"nodes": nodes})
+		})
+		nodes = nodes[:0]
+		return err
+	}
+
+	var uploadErr error
+	objs.Iterate(func(o *engine.Object) bool {
+		nodes = append(nodes, map[string]any{
+			"sid":   o.SID().String(),
+			"kinds": []string{o.Type().String()},
+			"properties": map[string]any{
+				"name": o.Label(),
+			},
+		})
+		if onNode != nil {
+			onNode()
+		}
+		if len(nodes) >= u.config.BatchSize {
+			uploadErr = flush()
+		}
+		return uploadErr == nil
+	})
+	if uploadErr != nil {
+		return uploadErr
+	}
+
+	return flush()
+}
+
+func (u *Uploader) uploadEdges(ctx context.Context, session neo4j.SessionWithContext, objs *engine.Objects, onEdge func()) error {
+	var edges []map[string]any
+
+	flush := func() error {
+		if len(edges) == 0 {
+			return nil
+		}
+		_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+			return tx.Run(ctx, `
+				UNWIND $edges AS edge
+				MATCH (source:AdalancheObject {sid: edge.source})
+				MATCH (target:AdalancheObject {sid: edge.target})
+				MERGE (source)-[r:ADALANCHE_EDGE {kind: edge.kind}]->(target)
+				SET r.probability = edge.probability
+			`, map[string]any{"edges": edges})
+		})
+		edges = edges[:0]
+		return err
+	}
+
+	var uploadErr error
+	objs.Iterate(func(o *engine.Object) bool {
+		o.Edges(engine.Out).Range(func(target *engine.Object, edgebits engine.EdgeBitmap) bool {
+			edgebits.Range(func(edge engine.Edge) bool {
+				edges = append(edges, map[string]any{
+					"source":      o.SID().String(),
+					"target":      target.SID().String(),
+					"kind":        edge.String(),
+					"probability": edge.EffectiveProbability(edgebits.MaxProbability(o, target)),
+				})
+				if onEdge != nil {
+					onEdge()
+				}
+				if len(edges) >= u.config.BatchSize {
+					uploadErr = flush()
+				}
+				return uploadErr == nil
+			})
+			return uploadErr == nil
+		})
+		return uploadErr == nil
+	})
+	if uploadErr != nil {
+		return uploadErr
+	}
+
+	return flush()
+}
@@ -0,0 +1,300 @@
+package analyze
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/lkarlslund/adalanche/modules/engine"
+	"github.com/lkarlslund/adalanche/modules/version"
+)
+
+// EdgeHop is a single edge in a Finding's path, carrying the probability
+// from its registered engine.RegisterProbabilityCalculator.
+type EdgeHop struct {
+	SourceSID   string             `json:"source_sid"`
+	TargetSID   string             `json:"target_sid"`
+	Edge        string             `json:"edge"`
+	Probability engine.Probability `json:"probability"`
+}
+
+// Finding is a single attack path from a source object to a target
+// object, for consumption by CI dashboards or SIEM ingestion.
+type Finding struct {
+	SourceSID string    `json:"source_sid"`
+	TargetSID string    `json:"target_sid"`
+	Path      []EdgeHop `json:"path"`
+	// Score is the aggregate path probability - the lowest probability
+	// among the path's edges, since an attacker needs every hop to
+	// succeed.
+	Score engine.Probability `json:"score"`
+	// RuleID identifies the finding's class, derived from the terminal
+	// edge, e.g. "adalanche.EdgeSeDebug".
+	RuleID string `json:"rule_id"`
+}
+
+// FindAttackPaths walks objs, breadth-first, for paths from the object
+// with SID from to the object with SID to where every edge on the path
+// has at least minProbability. If from or to is empty, all objects are
+// used as that endpoint. Edges disabled by a scoring profile are not
+// followed. onSource, if non-nil, is called once per source object
+// searched from, for progress reporting.
+func FindAttackPaths(objs *engine.Objects, from, to string, minProbability engine.Probability, onSource func()) ([]Finding, error) {
+	var sources, targets []*engine.Object
+
+	objs.Iterate(func(o *engine.Object) bool {
+		sid := o.SID().String()
+		if from == "" || sid == from {
+			sources = append(sources, o)
+		}
+		if to == "" || sid == to {
+			targets = append(targets, o)
+		}
+		return true
+	})
+
+	targetset := make(map[string]bool, len(targets))
+	for _, t := range targets {
+		targetset[t.SID().String()] = true
+	}
+
+	var findings []Finding
+	for _, source := range sources {
+		findings = append(findings, bfsFindPaths(source, targetset, minProbability)...)
+		if onSource != nil {
+			onSource()
+		}
+	}
+
+	return findings, nil
+}
+
+// edgeCandidate is one qualifying edge kind from a source to a target,
+// considered by selectBestHop when a target is reachable via more than
+// one edge kind at once (e.g. both EdgeMemberOfGroup and
+// EdgeLocalAdminRights to the same object).
+type edgeCandidate struct {
+	Edge        engine.Edge
+	Probability engine.Probability
+}
+
+// selectBestHop picks the strongest of a target's qualifying edges (those
+// at or above minProbability) to continue the BFS on. Only the winner is
+// used to expand the target further - a target with several qualifying
+// edge kinds must still only be enqueued once, or each of its downstream
+// subtrees gets re-walked once per qualifying edge kind.
+func selectBestHop(candidates []edgeCandidate, minProbability engine.Probability) (edgeCandidate, bool) {
+	var best edgeCandidate
+	found := false
+	for _, c := range candidates {
+		if c.Probability < minProbability {
+			continue
+		}
+		if !found || c.Probability > best.Probability {
+			best = c
+			found = true
+		}
+	}
+	return best, found
+}
+
+func bfsFindPaths(source *engine.Object, targetset map[string]bool, minProbability engine.Probability) []Finding {
+	type queueitem struct {
+		object *engine.Object
+		path   []EdgeHop
+	}
+
+	var findings []Finding
+	visited := map[string]bool{source.SID().String(): true}
+	queue := []queueitem{{object: source}}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		current.object.Edges(engine.Out).Range(func(target *engine.Object, edgebits engine.EdgeBitmap) bool {
+			targetsid := target.SID().String()
+			if visited[targetsid] {
+				return true
+			}
+
+			var candidates []edgeCandidate
+			edgebits.Range(func(edge engine.Edge) bool {
+				if edge.Disabled() {
+					return true
+				}
+				candidates = append(candidates, edgeCandidate{
+					Edge:        edge,
+					Probability: EdgeProbability(edge, edgebits, current.object, target),
+				})
+				return true
+			})
+
+			if targetset[targetsid] {
+				for _, c := range candidates {
+					if c.Probability < minProbability {
+						continue
+					}
+					path := append(append([]EdgeHop{}, current.path...), EdgeHop{
+						SourceSID:   current.object.SID().String(),
+						TargetSID:   targetsid,
+						Edge:        c.Edge.String(),
+						Probability: c.Probability,
+					})
+					findings = append(findings, Finding{
+						SourceSID: source.SID().String(),
+						TargetSID: targetsid,
+						Path:      path,
+						Score:     pathScore(path),
+						RuleID:    "adalanche.Edge" + c.Edge.String(),
+					})
+				}
+			}
+
+			if best, ok := selectBestHop(candidates, minProbability); ok {
+				visited[targetsid] = true
+				path := append(append([]EdgeHop{}, current.path...), EdgeHop{
+					SourceSID:   current.object.SID().String(),
+					TargetSID:   targetsid,
+					Edge:        best.Edge.String(),
+					Probability: best.Probability,
+				})
+				queue = append(queue, queueitem{object: target, path: path})
+			}
+
+			return true
+		})
+	}
+
+	return findings
+}
+
+func pathScore(path []EdgeHop) engine.Probability {
+	if len(path) == 0 {
+		return 0
+	}
+	score := path[0].Probability
+	for _, hop := range path[1:] {
+		if hop.Probability < score {
+			score = hop.Probability
+		}
+	}
+	return score
+}
+
+// ExportJSONL writes findings as newline-delimited JSON, one finding per
+// line.
+func ExportJSONL(findings []Finding, w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	for _, finding := range findings {
+		if err := encoder.Encode(finding); err != nil {
+			return fmt.Errorf("encoding finding: %w", err)
+		}
+	}
+	return nil
+}
+
+// sarifLog and friends are a minimal subset of the SARIF 2.1.0 schema,
+// just enough to carry Adalanche findings into GitHub code scanning.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name    string      `json:"name"`
+	Version string      `json:"version"`
+	Rules   []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleID     string         `json:"ruleId"`
+	Level      string         `json:"level"`
+	Message    sarifMessage   `json:"message"`
+	Properties map[string]any `json:"properties"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+// ExportSARIF writes findings as a SARIF 2.1.0 log, with one result per
+// finding, so adalanche runs can be gated on in GitHub code scanning.
+func ExportSARIF(findings []Finding, w io.Writer) error {
+	log := sarifLog{
+		Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{
+			{
+				Tool: sarifTool{
+					Driver: sarifDriver{
+						Name:    "adalanche",
+						Version: version.ProgramVersionShort(),
+					},
+				},
+			},
+		},
+	}
+
+	seenrules := map[string]bool{}
+	for _, finding := range findings {
+		if !seenrules[finding.RuleID] {
+			log.Runs[0].Tool.Driver.Rules = append(log.Runs[0].Tool.Driver.Rules, sarifRule{ID: finding.RuleID})
+			seenrules[finding.RuleID] = true
+		}
+
+		log.Runs[0].Results = append(log.Runs[0].Results, sarifResult{
+			RuleID: finding.RuleID,
+			Level:  sarifLevel(finding.Score),
+			Message: sarifMessage{
+				Text: fmt.Sprintf("Attack path from %v to %v via %v (score %v)", finding.SourceSID, finding.TargetSID, edgeChainString(finding.Path), finding.Score),
+			},
+			Properties: map[string]any{
+				"sourceSid": finding.SourceSID,
+				"targetSid": finding.TargetSID,
+				"score":     finding.Score,
+				"path":      finding.Path,
+			},
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(log)
+}
+
+func sarifLevel(score engine.Probability) string {
+	switch {
+	case score >= 75:
+		return "error"
+	case score >= 40:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+func edgeChainString(path []EdgeHop) string {
+	var s string
+	for i, hop := range path {
+		if i > 0 {
+			s += " -> "
+		}
+		s += hop.Edge
+	}
+	return s
+}
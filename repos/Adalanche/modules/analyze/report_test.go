@@ -0,0 +1,120 @@
+package analyze
+
+import (
+	"testing"
+
+	"github.com/lkarlslund/adalanche/modules/engine"
+)
+
+func TestPathScore(t *testing.T) {
+	tests := []struct {
+		name string
+		path []EdgeHop
+		want engine.Probability
+	}{
+		{
+			name: "empty path",
+			path: nil,
+			want: 0,
+		},
+		{
+			name: "single hop",
+			path: []EdgeHop{{Probability: 50}},
+			want: 50,
+		},
+		{
+			name: "score is the minimum across hops",
+			path: []EdgeHop{{Probability: 80}, {Probability: 30}, {Probability: 60}},
+			want: 30,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pathScore(tt.path); got != tt.want {
+				t.Errorf("pathScore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestSelectBestHop is a regression test for a bug where a target
+// reachable via more than one qualifying edge kind (e.g. both
+// EdgeMemberOfGroup and EdgeLocalAdminRights to the same object) got
+// enqueued once per qualifying edge, re-walking its downstream subtree
+// once per edge kind instead of once per node.
+func TestSelectBestHop(t *testing.T) {
+	edgeWeak := engine.NewEdge("TestEdgeWeak")
+	edgeStrong := engine.NewEdge("TestEdgeStrong")
+
+	tests := []struct {
+		name           string
+		candidates     []edgeCandidate
+		minProbability engine.Probability
+		wantEdge       engine.Edge
+		wantFound      bool
+	}{
+		{
+			name:       "no candidates",
+			candidates: nil,
+			wantFound:  false,
+		},
+		{
+			name:           "single qualifying edge",
+			candidates:     []edgeCandidate{{Edge: edgeWeak, Probability: 50}},
+			minProbability: 10,
+			wantEdge:       edgeWeak,
+			wantFound:      true,
+		},
+		{
+			name: "multiple qualifying edges: exactly one winner, the strongest",
+			candidates: []edgeCandidate{
+				{Edge: edgeWeak, Probability: 30},
+				{Edge: edgeStrong, Probability: 80},
+			},
+			minProbability: 10,
+			wantEdge:       edgeStrong,
+			wantFound:      true,
+		},
+		{
+			name: "edges below the minimum are excluded",
+			candidates: []edgeCandidate{
+				{Edge: edgeWeak, Probability: 5},
+			},
+			minProbability: 10,
+			wantFound:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := selectBestHop(tt.candidates, tt.minProbability)
+			if found != tt.wantFound {
+				t.Fatalf("selectBestHop() found = %v, want %v", found, tt.wantFound)
+			}
+			if found && got.Edge != tt.wantEdge {
+				t.Errorf("selectBestHop() edge = %v, want %v", got.Edge, tt.wantEdge)
+			}
+		})
+	}
+}
+
+func TestSarifLevel(t *testing.T) {
+	tests := []struct {
+		score engine.Probability
+		want  string
+	}{
+		{score: 0, want: "note"},
+		{score: 39, want: "note"},
+		{score: 40, want: "warning"},
+		{score: 74, want: "warning"},
+		{score: 75, want: "error"},
+		{score: 100, want: "error"},
+	}
+
+	for _, tt := range tests {
+		if got := sarifLevel(tt.score); got != tt.want {
+			t.Errorf("sarifLevel(%v) = %v, want %v", tt.score, got, tt.want)
+		}
+	}
+}
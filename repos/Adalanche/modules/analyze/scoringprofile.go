@@ -0,0 +1,99 @@
+package analyze
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/lkarlslund/adalanche/modules/engine"
+	"gopkg.in/yaml.v3"
+)
+
+// EdgeOverride describes how a scoring profile wants to change a single
+// edge's behaviour: its probability, the tags it carries, or whether it
+// should be excluded from analysis entirely.
+type EdgeOverride struct {
+	Probability *engine.Probability `yaml:"probability"`
+	Tags        []string            `yaml:"tags"`
+	Disabled    bool                `yaml:"disabled"`
+}
+
+// ScoringProfile is a named set of per-edge overrides, loaded from YAML,
+// that can be layered on top of Adalanche's built-in probability
+// calculators to tune an analysis for a particular threat model (e.g.
+// assumed-breach vs. external, workstation vs. server).
+type ScoringProfile struct {
+	Name  string                  `yaml:"name"`
+	Edges map[string]EdgeOverride `yaml:"edges"`
+}
+
+// LoadScoringProfile reads a single scoring profile YAML file.
+func LoadScoringProfile(path string) (ScoringProfile, error) {
+	var profile ScoringProfile
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return profile, fmt.Errorf("reading scoring profile %v: %w", path, err)
+	}
+	if err := yaml.Unmarshal(raw, &profile); err != nil {
+		return profile, fmt.Errorf("parsing scoring profile %v: %w", path, err)
+	}
+	if profile.Name == "" {
+		profile.Name = path
+	}
+	return profile, nil
+}
+
+// MergeScoringProfiles layers overlay profiles on top of a base, later
+// profiles in the slice taking precedence on a per-edge basis. This lets
+// callers compose a base profile (e.g. "external") with a smaller overlay
+// (e.g. "workstation-only") instead of duplicating the full edge list.
+func MergeScoringProfiles(profiles ...ScoringProfile) ScoringProfile {
+	merged := ScoringProfile{Edges: map[string]EdgeOverride{}}
+	for _, profile := range profiles {
+		if merged.Name == "" {
+			merged.Name = profile.Name
+		}
+		for name, override := range profile.Edges {
+			merged.Edges[name] = override
+		}
+	}
+	return merged
+}
+
+// EdgeProbability is the probability export and reporting code should use
+// for an edge between source and target: it consults any scoring profile
+// override (or disablement) first, falling back to the edge's registered
+// probability calculator (via edgebits.MaxProbability) only if no
+// override applies.
+func EdgeProbability(edge engine.Edge, edgebits engine.EdgeBitmap, source, target *engine.Object) engine.Probability {
+	return edge.EffectiveProbability(edgebits.MaxProbability(source, target))
+}
+
+// ApplyScoringProfile applies profile's overrides to the registered edges,
+// by name, before analysis runs. Edges named in the profile that don't
+// resolve to a registered engine.Edge are reported as warnings rather than
+// failing the whole run, since profiles are meant to be shared across
+// Adalanche versions that may not register the exact same edge set.
+func ApplyScoringProfile(profile ScoringProfile) []error {
+	var warnings []error
+
+	for name, override := range profile.Edges {
+		edge, found := engine.EdgeFromString(name)
+		if !found {
+			warnings = append(warnings, fmt.Errorf("scoring profile %v: unknown edge %q", profile.Name, name))
+			continue
+		}
+
+		if override.Probability != nil {
+			edge.SetProbabilityOverride(*override.Probability)
+		}
+		for _, tag := range override.Tags {
+			edge.Tag(tag)
+		}
+		if override.Disabled {
+			edge.Disable()
+		}
+	}
+
+	return warnings
+}
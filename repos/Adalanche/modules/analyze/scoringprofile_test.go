@@ -0,0 +1,61 @@
+package analyze
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/lkarlslund/adalanche/modules/engine"
+)
+
+func TestMergeScoringProfiles(t *testing.T) {
+	probability30 := engine.Probability(30)
+	probability80 := engine.Probability(80)
+
+	base := ScoringProfile{
+		Name: "base",
+		Edges: map[string]EdgeOverride{
+			"SeImpersonate": {Probability: &probability30},
+			"RDPRights":     {Disabled: true},
+		},
+	}
+	overlay := ScoringProfile{
+		Name: "overlay",
+		Edges: map[string]EdgeOverride{
+			"SeImpersonate": {Probability: &probability80},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		profiles []ScoringProfile
+		want     map[string]EdgeOverride
+	}{
+		{
+			name:     "single profile",
+			profiles: []ScoringProfile{base},
+			want:     base.Edges,
+		},
+		{
+			name:     "overlay wins for shared edge",
+			profiles: []ScoringProfile{base, overlay},
+			want: map[string]EdgeOverride{
+				"SeImpersonate": {Probability: &probability80},
+				"RDPRights":     {Disabled: true},
+			},
+		},
+		{
+			name:     "no profiles",
+			profiles: nil,
+			want:     map[string]EdgeOverride{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MergeScoringProfiles(tt.profiles...)
+			if !reflect.DeepEqual(got.Edges, tt.want) {
+				t.Errorf("MergeScoringProfiles() edges = %+v, want %+v", got.Edges, tt.want)
+			}
+		})
+	}
+}
@@ -6,15 +6,18 @@ import (
 	"io/fs"
 	"net/http"
 	"os"
+	"strconv"
 	"text/template"
 	"time"
 
 	"github.com/gin-contrib/pprof"
 	"github.com/gin-contrib/static"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/lkarlslund/adalanche/modules/engine"
 	"github.com/lkarlslund/adalanche/modules/ui"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 //go:embed html/*
@@ -67,21 +70,39 @@ func NewWebservice() *webservice {
 	gin.SetMode(gin.ReleaseMode)
 
 	ws.Router.Use(func(c *gin.Context) {
+		requestid := c.GetHeader("X-Request-Id")
+		if requestid == "" {
+			requestid = uuid.NewString()
+		}
+		c.Header("X-Request-Id", requestid)
+
 		start := time.Now() // Start timer
-		path := c.Request.URL.Path
+		route := c.FullPath()
+		if route == "" {
+			route = c.Request.URL.Path
+		}
 
 		// Process request
 		c.Next()
 
+		duration := time.Since(start)
+		status := c.Writer.Status()
+
 		logger := ui.Info()
-		if c.Writer.Status() >= 500 {
+		if status >= 500 {
 			logger = ui.Error()
 		}
+		logger.Msgf("%s %s (%v) %v, %v bytes, remote=%v, request_id=%v", c.Request.Method, route, status, duration, c.Writer.Size(), c.ClientIP(), requestid)
 
-		logger.Msgf("%s %s (%v) %v, %v bytes", c.Request.Method, path, c.Writer.Status(), time.Since(start), c.Writer.Size())
+		statusLabel := strconv.Itoa(status)
+		httpRequestsTotal.WithLabelValues(route, c.Request.Method, statusLabel).Inc()
+		httpRequestDuration.WithLabelValues(route, c.Request.Method, statusLabel).Observe(duration.Seconds())
+		httpResponseSize.WithLabelValues(route, c.Request.Method).Observe(float64(c.Writer.Size()))
 	})
 	ws.Router.Use(gin.Recovery()) // adds the default recovery middleware
 
+	ws.Router.GET("/metrics", gin.WrapH(promhttp.Handler()))
+
 	htmlFs, _ := fs.Sub(embeddedassets, "html")
 	ws.AddFS(http.FS(htmlFs))
 
@@ -102,6 +123,7 @@ func (w *webservice) QuitChan() <-chan bool {
 
 func (w *webservice) Start(bind string, objs *engine.Objects, localhtml []string) error {
 	w.Objs = objs
+	updateGraphMetrics(objs)
 
 	// Profiling
 	pprof.Register(w.Router)
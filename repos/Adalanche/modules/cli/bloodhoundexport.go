@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"os"
+	"time"
+
+	"github.com/lkarlslund/adalanche/modules/analyze"
+	"github.com/lkarlslund/adalanche/modules/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	bloodhoundExportCmd = &cobra.Command{
+		Use:   "bloodhound-export",
+		Short: "Export the analyzed object graph as BloodHound OpenGraph JSON",
+		RunE:  bloodhoundExport,
+	}
+
+	bloodhoundExportOutput = bloodhoundExportCmd.Flags().String("output", "opengraph.json", "File to write the OpenGraph JSON to (with --split: the directory to write nodes.json/edges.json into)")
+	bloodhoundExportSplit  = bloodhoundExportCmd.Flags().Bool("split", false, "Write separate nodes.json/edges.json files into --output instead of one combined document")
+)
+
+func init() {
+	Root.AddCommand(bloodhoundExportCmd)
+}
+
+func bloodhoundExport(cmd *cobra.Command, args []string) error {
+	objs, err := loadAnalyzedObjects()
+	if err != nil {
+		return err
+	}
+
+	bar := Progress.StartPass("bloodhound-export: objects", 0)
+	onObject := func() { bar.Increment() }
+	defer bar.Finish()
+
+	start := time.Now()
+	defer func() { analyze.RecordPassDuration("bloodhound-export", time.Since(start)) }()
+
+	if *bloodhoundExportSplit {
+		if err := os.MkdirAll(*bloodhoundExportOutput, 0755); err != nil {
+			return err
+		}
+		if err := analyze.ExportOpenGraphSplit(objs, onObject, *bloodhoundExportOutput); err != nil {
+			return err
+		}
+		ui.Info().Msgf("Wrote nodes.json and edges.json to %v", *bloodhoundExportOutput)
+		return nil
+	}
+
+	f, err := os.Create(*bloodhoundExportOutput)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := analyze.ExportOpenGraph(objs, onObject, f); err != nil {
+		return err
+	}
+	ui.Info().Msgf("Wrote BloodHound OpenGraph export to %v", *bloodhoundExportOutput)
+	return nil
+}
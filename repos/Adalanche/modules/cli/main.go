@@ -5,10 +5,12 @@ import (
 	"net/http"
 	_ "net/http/pprof"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"runtime/debug"
 	"runtime/pprof"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/felixge/fgtrace"
@@ -210,6 +212,8 @@ func init() {
 		return nil
 	}
 	Root.PersistentPostRunE = func(cmd *cobra.Command, args []string) error {
+		Progress.Finish()
+
 		stopfgtrace <- true
 		stopprofile <- true
 		profilewriters.Wait()
@@ -226,11 +230,30 @@ func CliMainEntryPoint() error {
 		Root.SetArgs(OverrideArgs)
 	}
 
-	err := Root.Execute()
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigs)
 
-	if err == nil {
-		ui.Info().Msgf("Terminating successfully")
-	}
+	done := make(chan error, 1)
+	go func() {
+		done <- Root.Execute()
+	}()
+
+	select {
+	case err := <-done:
+		if err == nil {
+			ui.Info().Msgf("Terminating successfully")
+		}
+		return err
+	case <-sigs:
+		ui.Info().Msg("Interrupted, finishing up ...")
+
+		Progress.Finish()
 
-	return err
-}
\ No newline at end of file
+		stopfgtrace <- true
+		stopprofile <- true
+		profilewriters.Wait()
+
+		return fmt.Errorf("interrupted")
+	}
+}
@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"context"
+	"time"
+
+	"github.com/lkarlslund/adalanche/modules/analyze"
+	analyzeneo4j "github.com/lkarlslund/adalanche/modules/analyze/neo4j"
+	"github.com/lkarlslund/adalanche/modules/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	neo4jUploadCmd = &cobra.Command{
+		Use:   "neo4j-upload",
+		Short: "Stream the analyzed object graph to a Neo4j instance over Bolt",
+		RunE:  neo4jUpload,
+	}
+
+	neo4jURI       = neo4jUploadCmd.Flags().String("uri", "bolt://localhost:7687", "Neo4j Bolt URI")
+	neo4jUsername  = neo4jUploadCmd.Flags().String("username", "neo4j", "Neo4j username")
+	neo4jPassword  = neo4jUploadCmd.Flags().String("password", "", "Neo4j password")
+	neo4jDatabase  = neo4jUploadCmd.Flags().String("database", "neo4j", "Neo4j database name")
+	neo4jBatchSize = neo4jUploadCmd.Flags().Int("batchsize", 1000, "Number of nodes/edges per UNWIND/MERGE batch")
+)
+
+func init() {
+	Root.AddCommand(neo4jUploadCmd)
+}
+
+func neo4jUpload(cmd *cobra.Command, args []string) error {
+	objs, err := loadAnalyzedObjects()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	uploader, err := analyzeneo4j.NewUploader(ctx, analyzeneo4j.Config{
+		URI:       *neo4jURI,
+		Username:  *neo4jUsername,
+		Password:  *neo4jPassword,
+		Database:  *neo4jDatabase,
+		BatchSize: *neo4jBatchSize,
+	})
+	if err != nil {
+		return err
+	}
+	defer uploader.Close(ctx)
+
+	ui.Info().Msgf("Uploading analyzed objects to %v ...", *neo4jURI)
+
+	nodebar := Progress.StartPass("neo4j-upload: nodes", 0)
+	edgebar := Progress.StartPass("neo4j-upload: edges", 0)
+	defer nodebar.Finish()
+	defer edgebar.Finish()
+
+	start := time.Now()
+	defer func() { analyze.RecordPassDuration("neo4j-upload", time.Since(start)) }()
+
+	return uploader.Upload(ctx, objs, func() { nodebar.Increment() }, func() { edgebar.Increment() })
+}
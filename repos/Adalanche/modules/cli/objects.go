@@ -0,0 +1,23 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/lkarlslund/adalanche/modules/engine"
+)
+
+// loadObjectsFunc is engine.LoadObjects, indirected so tests can substitute
+// a fake loader without needing a real analyzed data folder on disk.
+var loadObjectsFunc = engine.LoadObjects
+
+// loadAnalyzedObjects loads the merged and analyzed object graph from the
+// current datapath, for use by export and reporting subcommands that only
+// need read-only access to the results of a previously completed analysis
+// run (they don't repeat collection, merging or analysis themselves).
+func loadAnalyzedObjects() (*engine.Objects, error) {
+	objs, err := loadObjectsFunc(*Datapath)
+	if err != nil {
+		return nil, fmt.Errorf("loading analyzed objects from %v: %w", *Datapath, err)
+	}
+	return objs, nil
+}
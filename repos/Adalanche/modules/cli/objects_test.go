@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/lkarlslund/adalanche/modules/engine"
+)
+
+func TestLoadAnalyzedObjects(t *testing.T) {
+	originalLoader := loadObjectsFunc
+	originalDatapath := *Datapath
+	defer func() {
+		loadObjectsFunc = originalLoader
+		*Datapath = originalDatapath
+	}()
+
+	*Datapath = "testdata-no-analyzed-objects"
+
+	t.Run("missing or empty data folder surfaces a wrapped, datapath-qualified error", func(t *testing.T) {
+		wantErr := errors.New("no analyzed objects found")
+		loadObjectsFunc = func(datapath string) (*engine.Objects, error) {
+			if datapath != *Datapath {
+				t.Errorf("loadObjectsFunc called with %q, want %q", datapath, *Datapath)
+			}
+			return nil, wantErr
+		}
+
+		_, err := loadAnalyzedObjects()
+		if err == nil {
+			t.Fatal("expected an error when the data folder has no analyzed objects yet")
+		}
+		if !errors.Is(err, wantErr) {
+			t.Errorf("loadAnalyzedObjects() error = %v, want it to wrap %v", err, wantErr)
+		}
+		if !strings.Contains(err.Error(), *Datapath) {
+			t.Errorf("loadAnalyzedObjects() error = %v, want it to mention datapath %q", err, *Datapath)
+		}
+	})
+
+	t.Run("happy path returns the loaded objects unchanged", func(t *testing.T) {
+		want := &engine.Objects{}
+		loadObjectsFunc = func(datapath string) (*engine.Objects, error) {
+			return want, nil
+		}
+
+		got, err := loadAnalyzedObjects()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != want {
+			t.Errorf("loadAnalyzedObjects() = %p, want %p", got, want)
+		}
+	})
+}
@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"os"
+	"sync"
+
+	"github.com/cheggaaa/pb/v3"
+	"github.com/mattn/go-isatty"
+)
+
+var (
+	noProgress = Root.PersistentFlags().Bool("no-progress", false, "Disable progress bars, even on an interactive terminal")
+	silent     = Root.PersistentFlags().Bool("silent", false, "Suppress progress bars, but keep error output")
+
+	// Progress renders one bar per named pass a subcommand reports
+	// progress for (e.g. "bloodhound-export: objects", "neo4j-upload:
+	// nodes"/"edges", "report: sources searched"). It is the single
+	// instance those passes report progress to; callers that don't want
+	// a visible bar (non-TTY, --no-progress, --silent) still get one
+	// back from StartPass, it just never renders.
+	//
+	// This is not yet hooked into collection or the merge/edge
+	// resolution/SID translation/probability calculation passes that
+	// produce the analyzed graph in the first place - that pipeline
+	// lives upstream of loadAnalyzedObjects and isn't part of this
+	// command set. Only the bulk passes the export/report subcommands
+	// themselves run over the already-analyzed graph are instrumented.
+	Progress = &progressRenderer{}
+)
+
+// progressRenderer owns a pb.Pool of progress bars, one per named pass,
+// and is safe to drive from multiple goroutines.
+type progressRenderer struct {
+	mu      sync.Mutex
+	pool    *pb.Pool
+	bars    map[string]*pb.ProgressBar
+	enabled bool
+	started bool
+}
+
+func progressEnabled() bool {
+	if *silent || *noProgress {
+		return false
+	}
+	return isatty.IsTerminal(os.Stdout.Fd())
+}
+
+// StartPass returns a progress bar for the named pass, creating it (and
+// the shared pool, on first use) if needed. When progress bars
+// are disabled the returned bar is a no-op bar that's safe to call
+// Increment/SetTotal/Finish on.
+func (p *progressRenderer) StartPass(name string, total int64) *pb.ProgressBar {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.bars == nil {
+		p.bars = map[string]*pb.ProgressBar{}
+		p.enabled = progressEnabled()
+	}
+
+	if bar, found := p.bars[name]; found {
+		bar.SetTotal(total)
+		return bar
+	}
+
+	bar := pb.New64(total)
+	bar.Set(pb.Bytes, false)
+	bar.SetTemplateString(`{{ ` + "`" + name + "`" + ` }} {{ bar . }} {{ counters . }} {{ rtime . "%s" }} {{ speed . }}`)
+
+	if p.enabled {
+		if p.pool == nil {
+			p.pool = pb.NewPool()
+			p.pool.Start()
+			p.started = true
+		}
+		p.pool.Add(bar)
+	}
+
+	p.bars[name] = bar
+	return bar
+}
+
+// Finish stops all progress bars and the underlying pool, if one was
+// started. It's safe to call more than once, and safe to call even if no
+// bars were ever created (e.g. the run was interrupted before analysis
+// started).
+func (p *progressRenderer) Finish() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, bar := range p.bars {
+		bar.Finish()
+	}
+	if p.started {
+		p.pool.Stop()
+		p.started = false
+	}
+}
@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/lkarlslund/adalanche/modules/analyze"
+	"github.com/lkarlslund/adalanche/modules/engine"
+	"github.com/lkarlslund/adalanche/modules/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	reportCmd = &cobra.Command{
+		Use:   "report",
+		Short: "Export attack path findings as SARIF or JSON-Lines for CI integration",
+		RunE:  report,
+	}
+
+	reportFormat         = reportCmd.Flags().String("format", "sarif", "Output format: sarif or jsonl")
+	reportOutput         = reportCmd.Flags().String("output", "", "File to write the report to (defaults to stdout)")
+	reportMinProbability = reportCmd.Flags().Int("min-probability", 0, "Only include findings whose aggregate path score is at least this probability")
+	reportFrom           = reportCmd.Flags().String("from", "", "Only include findings starting at this SID (default: all objects)")
+	reportTo             = reportCmd.Flags().String("to", "", "Only include findings ending at this SID (default: all objects)")
+)
+
+func init() {
+	Root.AddCommand(reportCmd)
+}
+
+func report(cmd *cobra.Command, args []string) error {
+	objs, err := loadAnalyzedObjects()
+	if err != nil {
+		return err
+	}
+
+	bar := Progress.StartPass("report: sources searched", 0)
+	defer bar.Finish()
+
+	start := time.Now()
+	defer func() { analyze.RecordPassDuration("report", time.Since(start)) }()
+
+	findings, err := analyze.FindAttackPaths(objs, *reportFrom, *reportTo, engine.Probability(*reportMinProbability), func() { bar.Increment() })
+	if err != nil {
+		return err
+	}
+
+	out := os.Stdout
+	if *reportOutput != "" {
+		f, err := os.Create(*reportOutput)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+
+	switch *reportFormat {
+	case "sarif":
+		err = analyze.ExportSARIF(findings, out)
+	case "jsonl":
+		err = analyze.ExportJSONL(findings, out)
+	default:
+		return fmt.Errorf("unknown report format %q, must be sarif or jsonl", *reportFormat)
+	}
+	if err != nil {
+		return err
+	}
+
+	ui.Info().Msgf("Wrote %v findings (%v)", len(findings), *reportFormat)
+	return nil
+}
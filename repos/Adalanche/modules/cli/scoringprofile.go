@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"github.com/lkarlslund/adalanche/modules/analyze"
+	"github.com/lkarlslund/adalanche/modules/ui"
+	"github.com/spf13/cobra"
+)
+
+// scoringProfiles holds --scoring-profile paths, in the order given on the
+// command line, so they can be composed base-first/overlay-last.
+var scoringProfiles = Root.PersistentFlags().StringSlice("scoring-profile", nil, "Scoring profile YAML file(s) to apply, base first then overlays (can be given multiple times)")
+
+func init() {
+	AddPreRunHook(loadScoringProfiles)
+}
+
+func loadScoringProfiles(cmd *cobra.Command, args []string) error {
+	if len(*scoringProfiles) == 0 {
+		return nil
+	}
+
+	profiles := make([]analyze.ScoringProfile, 0, len(*scoringProfiles))
+	for _, path := range *scoringProfiles {
+		profile, err := analyze.LoadScoringProfile(path)
+		if err != nil {
+			return err
+		}
+		profiles = append(profiles, profile)
+	}
+
+	merged := analyze.MergeScoringProfiles(profiles...)
+	for _, warning := range analyze.ApplyScoringProfile(merged) {
+		ui.Warn().Msg(warning.Error())
+	}
+
+	ui.Info().Msgf("Applied scoring profile(s): %v", *scoringProfiles)
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package engine
+
+import "sync"
+
+var (
+	edgeOverrideMutex        sync.RWMutex
+	edgeProbabilityOverrides = map[Edge]Probability{}
+	edgeDisabled             = map[Edge]bool{}
+	edgeNames                = map[string]Edge{}
+)
+
+// SetProbabilityOverride overrides this edge's probability for every pair
+// of objects, taking precedence over any calculator registered via
+// RegisterProbabilityCalculator. This is the hook scoring profiles use to
+// tune an analysis for a particular threat model without recompiling.
+func (e Edge) SetProbabilityOverride(p Probability) Edge {
+	edgeOverrideMutex.Lock()
+	defer edgeOverrideMutex.Unlock()
+	edgeProbabilityOverrides[e] = p
+	return e
+}
+
+// ClearProbabilityOverride removes a previously set override, reverting
+// the edge to its registered calculator (if any).
+func (e Edge) ClearProbabilityOverride() Edge {
+	edgeOverrideMutex.Lock()
+	defer edgeOverrideMutex.Unlock()
+	delete(edgeProbabilityOverrides, e)
+	return e
+}
+
+// ProbabilityOverride returns the probability a scoring profile has
+// overridden this edge to, if any.
+func (e Edge) ProbabilityOverride() (Probability, bool) {
+	edgeOverrideMutex.RLock()
+	defer edgeOverrideMutex.RUnlock()
+	p, found := edgeProbabilityOverrides[e]
+	return p, found
+}
+
+// Disable marks this edge as globally disabled. Disabled edges resolve to
+// probability 0 regardless of any registered calculator or override, and
+// are meant to be filtered out of analysis entirely by callers.
+func (e Edge) Disable() Edge {
+	edgeOverrideMutex.Lock()
+	defer edgeOverrideMutex.Unlock()
+	edgeDisabled[e] = true
+	return e
+}
+
+// Disabled reports whether a scoring profile has globally disabled this
+// edge.
+func (e Edge) Disabled() bool {
+	edgeOverrideMutex.RLock()
+	defer edgeOverrideMutex.RUnlock()
+	return edgeDisabled[e]
+}
+
+// RegisterEdgeName makes e resolvable by name through EdgeFromString.
+// Packages that define edges with NewEdge call this alongside, so scoring
+// profiles can refer to edges by their string name in YAML.
+func RegisterEdgeName(e Edge) Edge {
+	edgeOverrideMutex.Lock()
+	defer edgeOverrideMutex.Unlock()
+	edgeNames[e.String()] = e
+	return e
+}
+
+// EdgeFromString resolves an edge previously registered with
+// RegisterEdgeName by its name, as used in scoring profile YAML files.
+func EdgeFromString(name string) (Edge, bool) {
+	edgeOverrideMutex.RLock()
+	defer edgeOverrideMutex.RUnlock()
+	e, found := edgeNames[name]
+	return e, found
+}
+
+// EffectiveProbability is the single place analysis and export code
+// should go through to get an edge's probability: a disabled edge is 0
+// even if overridden, otherwise a scoring profile override wins, and only
+// then does it fall back to
+// calculated, the probability produced by the edge's registered
+// engine.RegisterProbabilityCalculator (or a static probability, for
+// edges that don't have one).
+func (e Edge) EffectiveProbability(calculated Probability) Probability {
+	if e.Disabled() {
+		return 0
+	}
+	if p, found := e.ProbabilityOverride(); found {
+		return p
+	}
+	return calculated
+}
@@ -85,6 +85,25 @@ var (
 	ObjectTypeShare = engine.NewObjectType("Share", "Share")
 )
 
+// init makes every edge defined in this file resolvable by name through
+// engine.EdgeFromString, so scoring profiles can target them by name
+// (e.g. "SeImpersonate", "RDPRights") instead of recompiling.
+func init() {
+	for _, edge := range []engine.Edge{
+		EdgeLocalAdminRights, EdgeLocalRDPRights, EdgeLocalDCOMRights, EdgeLocalSMSAdmins,
+		EdgeLocalSessionLastDay, EdgeLocalSessionLastWeek, EdgeLocalSessionLastMonth,
+		EdgeHasServiceAccountCredentials, EdgeHasAutoAdminLogonCredentials, EdgeRunsExecutable,
+		EdgeHosts, EdgeExecuted, EdgeMemberOfGroup, EdgeFileWrite, EdgeFileRead, EdgeShares,
+		EdgeRegistryOwns, EdgeRegistryWrite, EdgeRegistryModifyDACL, EdgeRegistryModifyOwner,
+		EdgeSeBackupPrivilege, EdgeSeRestorePrivilege, EdgeSeTakeOwnershipPrivilege,
+		EdgeSeAssignPrimaryToken, EdgeSeCreateToken, EdgeSeDebug, EdgeSeImpersonate,
+		EdgeSeLoadDriver, EdgeSeManageVolume, EdgeSeTakeOwnership, EdgeSeTrustedCredManAccess,
+		EdgeSeTcb, EdgeSeNetworkLogonRight, EdgeSIDCollision, EdgeControlsUpdates, EdgePublishes,
+	} {
+		engine.RegisterEdgeName(edge)
+	}
+}
+
 func MapSID(original, new, input windowssecurity.SID) windowssecurity.SID {
 	// If input SID is one longer than machine sid
 	if input.Components() == original.Components()+1 {